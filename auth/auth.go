@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package auth provides the pluggable signature schemes that a
+// chain.Transaction can use to authenticate its actor.
+package auth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Type identifies an authentication scheme. It is serialized alongside
+// the [Auth] payload so a verifier knows which implementation to use.
+type Type byte
+
+const (
+	SECP256K1 Type = iota
+	ED25519
+	BLS
+)
+
+func (t Type) String() string {
+	switch t {
+	case SECP256K1:
+		return "secp256k1"
+	case ED25519:
+		return "ed25519"
+	case BLS:
+		return "bls"
+	default:
+		return "unknown"
+	}
+}
+
+// Auth authenticates a transaction's actor and charges that actor for the
+// verification cost of its scheme. Implementations live under this
+// package (secp256k1, ed25519, bls) and are selected by [Type] at
+// marshal/unmarshal time.
+type Auth interface {
+	// ActorID returns the address that signed (and will be charged for)
+	// the transaction.
+	ActorID() common.Address
+
+	// Verify checks that the Auth payload authenticates [digest].
+	Verify(digest []byte) error
+
+	// Marshal writes the Auth payload (including its [Type] prefix) for
+	// inclusion in a signed transaction.
+	Marshal() ([]byte, error)
+
+	// ComputeUnits is the cost of verifying this Auth, in the Compute fee
+	// dimension (BLS verification costs more than secp256k1/ed25519).
+	ComputeUnits() uint64
+}
+
+// Unmarshaler parses the [Type]-prefixed bytes written by [Auth.Marshal]
+// back into an [Auth] of the matching scheme.
+type Unmarshaler func(b []byte) (Auth, error)
+
+var unmarshalers = map[Type]Unmarshaler{}
+
+// Register associates [t] with the [Unmarshaler] used to parse it. Each
+// scheme implementation calls this from an init func.
+func Register(t Type, u Unmarshaler) {
+	unmarshalers[t] = u
+}
+
+// Unmarshal dispatches to the [Unmarshaler] registered for the leading
+// [Type] byte of [b].
+func Unmarshal(b []byte) (Auth, error) {
+	if len(b) == 0 {
+		return nil, ErrEmptyAuth
+	}
+	u, ok := unmarshalers[Type(b[0])]
+	if !ok {
+		return nil, ErrUnknownAuthType
+	}
+	return u(b)
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func init() {
+	Register(BLS, UnmarshalBLS)
+}
+
+var _ Auth = &BLS{}
+
+// BLS authenticates a transaction with a BLS12-381 signature. Unlike
+// [SECP256K1]/[ED25519], BLS signatures over the same digest can be
+// aggregated with [AggregateBLS] so one pairing check covers all of them.
+// Signatures over different digests cannot be combined this way.
+type BLS struct {
+	PublicKey *bls.PublicKey
+	Signature *bls.Signature
+
+	rawPK []byte
+}
+
+// NewBLS wraps a raw BLS public key and signature.
+func NewBLS(pk *bls.PublicKey, sig *bls.Signature) *BLS {
+	return &BLS{PublicKey: pk, Signature: sig, rawPK: bls.PublicKeyToBytes(pk)}
+}
+
+// ActorID derives an address from the public key the same way
+// [SECP256K1]/[ED25519] derive one: the low 20 bytes of keccak256(pubkey).
+func (a *BLS) ActorID() common.Address {
+	return common.BytesToAddress(crypto.Keccak256(a.rawPK)[12:])
+}
+
+func (a *BLS) Verify(digest []byte) error {
+	if !bls.Verify(a.PublicKey, a.Signature, digest) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (a *BLS) Marshal() ([]byte, error) {
+	sigBytes := bls.SignatureToBytes(a.Signature)
+	b := make([]byte, 1+len(a.rawPK)+len(sigBytes))
+	b[0] = byte(BLS)
+	copy(b[1:], a.rawPK)
+	copy(b[1+len(a.rawPK):], sigBytes)
+	return b, nil
+}
+
+// ComputeUnits is higher than secp256k1/ed25519 to reflect the cost of a
+// pairing check. The same amount is charged whether or not the signature
+// is later aggregated with [AggregateBLS].
+func (a *BLS) ComputeUnits() uint64 {
+	return 200
+}
+
+const (
+	blsPublicKeyLen = bls.PublicKeyLen
+	blsSignatureLen = bls.SignatureLen
+)
+
+// UnmarshalBLS parses the [Type]-prefixed bytes written by [BLS.Marshal].
+func UnmarshalBLS(b []byte) (Auth, error) {
+	if len(b) != 1+blsPublicKeyLen+blsSignatureLen {
+		return nil, ErrInvalidAuthPayload
+	}
+	pk, err := bls.PublicKeyFromBytes(b[1 : 1+blsPublicKeyLen])
+	if err != nil {
+		return nil, err
+	}
+	sig, err := bls.SignatureFromBytes(b[1+blsPublicKeyLen:])
+	if err != nil {
+		return nil, err
+	}
+	return NewBLS(pk, sig), nil
+}
+
+// AggregateBLS combines the public keys and signatures of every BLS-signed
+// [Auth] that authenticates the same [digest] into a single aggregate
+// signature, letting the verifier pay for one pairing check instead of
+// len(auths). All [auths] must authenticate the same digest; callers are
+// responsible for grouping by digest first.
+func AggregateBLS(auths []*BLS) (*bls.PublicKey, *bls.Signature, error) {
+	pks := make([]*bls.PublicKey, len(auths))
+	sigs := make([]*bls.Signature, len(auths))
+	for i, a := range auths {
+		pks[i] = a.PublicKey
+		sigs[i] = a.Signature
+	}
+	aggPK, err := bls.AggregatePublicKeys(pks)
+	if err != nil {
+		return nil, nil, err
+	}
+	aggSig, err := bls.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aggPK, aggSig, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"crypto/ed25519"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func init() {
+	Register(ED25519, UnmarshalED25519)
+}
+
+var _ Auth = &ED25519{}
+
+// ED25519 authenticates a transaction with an Ed25519 public key and
+// signature. Unlike [SECP256K1], the public key cannot be recovered from
+// the signature alone, so both are carried in the payload.
+type ED25519 struct {
+	PublicKey ed25519.PublicKey
+	Signature []byte
+}
+
+// NewED25519 wraps a raw Ed25519 public key and signature.
+func NewED25519(pk ed25519.PublicKey, sig []byte) (*ED25519, error) {
+	if len(pk) != ed25519.PublicKeySize || len(sig) != ed25519.SignatureSize {
+		return nil, ErrInvalidAuthPayload
+	}
+	return &ED25519{PublicKey: pk, Signature: sig}, nil
+}
+
+// ActorID derives an address from the public key the same way
+// [SECP256K1] derives one: the low 20 bytes of keccak256(pubkey).
+func (a *ED25519) ActorID() common.Address {
+	return common.BytesToAddress(crypto.Keccak256(a.PublicKey)[12:])
+}
+
+func (a *ED25519) Verify(digest []byte) error {
+	if !ed25519.Verify(a.PublicKey, digest, a.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (a *ED25519) Marshal() ([]byte, error) {
+	b := make([]byte, 1+ed25519.PublicKeySize+ed25519.SignatureSize)
+	b[0] = byte(ED25519)
+	copy(b[1:], a.PublicKey)
+	copy(b[1+ed25519.PublicKeySize:], a.Signature)
+	return b, nil
+}
+
+func (a *ED25519) ComputeUnits() uint64 {
+	return 40
+}
+
+// UnmarshalED25519 parses the [Type]-prefixed bytes written by
+// [ED25519.Marshal].
+func UnmarshalED25519(b []byte) (Auth, error) {
+	if len(b) != 1+ed25519.PublicKeySize+ed25519.SignatureSize {
+		return nil, ErrInvalidAuthPayload
+	}
+	pk := make(ed25519.PublicKey, ed25519.PublicKeySize)
+	copy(pk, b[1:1+ed25519.PublicKeySize])
+	sig := make([]byte, ed25519.SignatureSize)
+	copy(sig, b[1+ed25519.PublicKeySize:])
+	return NewED25519(pk, sig)
+}
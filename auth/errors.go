@@ -0,0 +1,13 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import "errors"
+
+var (
+	ErrEmptyAuth          = errors.New("empty auth payload")
+	ErrUnknownAuthType    = errors.New("unknown auth type")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrInvalidAuthPayload = errors.New("invalid auth payload")
+)
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func init() {
+	Register(SECP256K1, UnmarshalSECP256K1)
+}
+
+var _ Auth = &SECP256K1{}
+
+// SECP256K1 is the original signing scheme used by spacesvm: an EIP-712
+// typed-data digest signed with a secp256k1 key, recovered to an address
+// the same way go-ethereum transactions are.
+type SECP256K1 struct {
+	Signature [crypto.SignatureLength]byte
+
+	actor common.Address
+}
+
+// NewSECP256K1 wraps a raw recoverable signature produced over an
+// EIP-712 digest.
+func NewSECP256K1(sig []byte) (*SECP256K1, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, ErrInvalidAuthPayload
+	}
+	a := &SECP256K1{}
+	copy(a.Signature[:], sig)
+	return a, nil
+}
+
+func (a *SECP256K1) ActorID() common.Address { return a.actor }
+
+func (a *SECP256K1) Verify(digest []byte) error {
+	pk, err := crypto.SigToPub(digest, a.Signature[:])
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	a.actor = crypto.PubkeyToAddress(*pk)
+	return nil
+}
+
+func (a *SECP256K1) Marshal() ([]byte, error) {
+	b := make([]byte, 1+crypto.SignatureLength)
+	b[0] = byte(SECP256K1)
+	copy(b[1:], a.Signature[:])
+	return b, nil
+}
+
+func (a *SECP256K1) ComputeUnits() uint64 {
+	return 100
+}
+
+// UnmarshalSECP256K1 parses the [Type]-prefixed bytes written by
+// [SECP256K1.Marshal].
+func UnmarshalSECP256K1(b []byte) (Auth, error) {
+	if len(b) != 1+crypto.SignatureLength {
+		return nil, ErrInvalidAuthPayload
+	}
+	return NewSECP256K1(b[1:])
+}
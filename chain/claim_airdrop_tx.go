@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/spacesvm/tdata"
+)
+
+const claimedAirdropPrefix = 0x5 // shares the top-level key prefix space with balances/prefixes/imports
+
+func claimedAirdropKey(index uint64) []byte {
+	k := make([]byte, 1+8)
+	k[0] = claimedAirdropPrefix
+	putUint64(k[1:], index)
+	return k
+}
+
+// HasClaimedAirdrop returns whether the airdrop leaf at [index] has
+// already been claimed, to protect against double-claims now that the
+// allocation lives in a Merkle tree instead of a per-address balance
+// write at genesis.
+func HasClaimedAirdrop(db database.KeyValueReader, index uint64) (bool, error) {
+	return db.Has(claimedAirdropKey(index))
+}
+
+func putClaimedAirdrop(db database.KeyValueWriter, index uint64) error {
+	return db.Put(claimedAirdropKey(index), nil)
+}
+
+var _ UnsignedTransaction = &ClaimAirdropTx{}
+
+// ClaimAirdropTx credits the genesis airdrop allocation for leaf [Index]
+// to the sender, proven against g.AirdropMerkleRoot rather than loaded
+// into every node's state at genesis.
+type ClaimAirdropTx struct {
+	*BaseTx `serialize:"true" json:"baseTx"`
+
+	// Index is this leaf's position in the sorted allocation list (and
+	// the value hashed alongside the address/units to prevent proofs
+	// from one leaf validating another).
+	Index uint64 `serialize:"true" json:"index"`
+
+	// Units is the amount allocated to this leaf, as committed into the
+	// tree alongside the sender's address. The allocation list may be
+	// non-uniform, so this must be the claimant-supplied value the leaf
+	// hash is recomputed against, not a single genesis-wide flat rate.
+	Units uint64 `serialize:"true" json:"units"`
+
+	// Proof is the list of sibling hashes, bottom-up, connecting this
+	// leaf to g.AirdropMerkleRoot.
+	Proof [][]byte `serialize:"true" json:"proof"`
+}
+
+func (c *ClaimAirdropTx) Execute(t *TransactionContext) error {
+	claimed, err := HasClaimedAirdrop(t.Database, c.Index)
+	if err != nil {
+		return err
+	}
+	if claimed {
+		return ErrAirdropAlreadyClaimed
+	}
+
+	if !VerifyAirdropMerkleProof(
+		t.Genesis.AirdropMerkleRoot,
+		c.Proof,
+		c.Index,
+		t.Sender,
+		c.Units,
+	) {
+		return ErrInvalidAirdropProof
+	}
+
+	if err := putClaimedAirdrop(t.Database, c.Index); err != nil {
+		return err
+	}
+	_, err = ModifyBalance(t.Database, t.Sender, true, c.Units)
+	return err
+}
+
+// LoadUnits reports the resources [c] consumes per [FeeDimension]: only
+// the base tx cost, since the proof is verified against a root already
+// held in genesis. The claimed-flag read and write and the balance credit
+// done by Execute are not charged separately.
+func (c *ClaimAirdropTx) LoadUnits(g *Genesis) Dimensions {
+	return Dimensions{
+		Compute: g.BaseTxUnits,
+	}
+}
+
+func (c *ClaimAirdropTx) Copy() UnsignedTransaction {
+	proof := make([][]byte, len(c.Proof))
+	for i, p := range c.Proof {
+		pc := make([]byte, len(p))
+		copy(pc, p)
+		proof[i] = pc
+	}
+	return &ClaimAirdropTx{
+		BaseTx: c.BaseTx.Copy(),
+		Index:  c.Index,
+		Units:  c.Units,
+		Proof:  proof,
+	}
+}
+
+func (c *ClaimAirdropTx) TypedData() tdata.TypedData {
+	return tdata.CreateTypedData(
+		c.Magic, ClaimAirdrop,
+		[]tdata.Type{
+			{Name: "blockID", Type: "string"},
+			{Name: "price", Type: "uint64"},
+			{Name: "index", Type: "uint64"},
+			{Name: "units", Type: "uint64"},
+		},
+		tdata.TypedDataMessage{
+			"blockID": c.BlockID.String(),
+			"price":   c.Price,
+			"index":   c.Index,
+			"units":   c.Units,
+		},
+	)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+// FeeDimension indexes a single axis of resource consumption that is
+// metered (and priced) independently of the others.
+type FeeDimension int
+
+const (
+	Bandwidth FeeDimension = iota
+	Compute
+	StorageRead
+	StorageAllocate
+	StorageWrite
+
+	FeeDimensions // must remain last; used as the length of [Dimensions]
+)
+
+// Dimensions is a fixed-length vector of per-[FeeDimension] unit counts
+// (consumption, price, or a rolling window, depending on context).
+//
+// LoadUnits(g *Genesis) Dimensions is implemented by every
+// UnsignedTransaction defined in this package (TransferTx, ExportTx,
+// ImportTx, ClaimAirdropTx). ClaimTx (referenced by name from
+// cmd/quarkcli/claim), SetTx, and LifelineTx are not themselves
+// present as files in this source tree, so they could not be given a
+// LoadUnits implementation here without guessing at fields this package
+// has never defined.
+type Dimensions [FeeDimensions]uint64
+
+// Add returns the element-wise sum of [d] and [o].
+func (d Dimensions) Add(o Dimensions) Dimensions {
+	var r Dimensions
+	for i := range d {
+		r[i] = d[i] + o[i]
+	}
+	return r
+}
+
+// Fee returns sum(d[i] * price[i]), the total fee owed for consuming [d]
+// units of each dimension at [price].
+func (d Dimensions) Fee(price Dimensions) uint64 {
+	var total uint64
+	for i := range d {
+		total += d[i] * price[i]
+	}
+	return total
+}
+
+// Exceeds returns true if any dimension of [d] is greater than the
+// corresponding dimension of [max].
+func (d Dimensions) Exceeds(max Dimensions) bool {
+	for i := range d {
+		if d[i] > max[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Remaining returns, per dimension, how much of [max] is left after [d]
+// has already been used (floored at 0). A block builder calls this with
+// the block's running usage and Genesis.MaxBlockUnits to find out how
+// much more it can pack into the block before hitting the cap.
+func (d Dimensions) Remaining(max Dimensions) Dimensions {
+	var r Dimensions
+	for i := range d {
+		if d[i] >= max[i] {
+			continue
+		}
+		r[i] = max[i] - d[i]
+	}
+	return r
+}
+
+// TightestDimension returns the [FeeDimension] with the least [remaining]
+// capacity relative to [max] — the dimension closest to becoming the
+// binding constraint on the block currently being built, and so the one a
+// mempool should rank transactions' fee-rate against. A dimension whose
+// [max] is 0 has no enforced capacity and is never returned unless every
+// dimension is uncapped, in which case Bandwidth (index 0) is returned.
+func TightestDimension(remaining, max Dimensions) FeeDimension {
+	tightest := FeeDimension(0)
+	found := false
+	for i := range remaining {
+		if max[i] == 0 {
+			continue
+		}
+		if !found {
+			tightest, found = FeeDimension(i), true
+			continue
+		}
+		// remaining[i]/max[i] < remaining[tightest]/max[tightest],
+		// cross-multiplied to stay in integer arithmetic.
+		if remaining[i]*max[tightest] < remaining[tightest]*max[i] {
+			tightest = FeeDimension(i)
+		}
+	}
+	return tightest
+}
+
+// FeeRatePerUnit returns [d]'s total fee at [price] per unit it consumes
+// of [dim] — the fee-rate a mempool sorts by once [TightestDimension] has
+// identified [dim] as the block's binding constraint. Returns 0 if [d]
+// doesn't consume [dim] at all, so it sorts behind any tx that does.
+func (d Dimensions) FeeRatePerUnit(price Dimensions, dim FeeDimension) uint64 {
+	if d[dim] == 0 {
+		return 0
+	}
+	return d.Fee(price) / d[dim]
+}
+
+// UpdateWindows applies the EIP-1559-style rolling window update
+// independently to each dimension: [price] moves up or down based on how
+// far [usage] over/undershot [windowTarget], bounded below by [min].
+func UpdateWindows(usage, windowTarget, price, min Dimensions) Dimensions {
+	var next Dimensions
+	for i := range usage {
+		next[i] = updateUnitPrice(usage[i], windowTarget[i], price[i], min[i])
+	}
+	return next
+}
+
+// updateUnitPrice nudges [price] up or down by 1/8th depending on whether
+// [usage] exceeded [target], never dropping below [min]. This mirrors
+// Ethereum's EIP-1559 base fee adjustment, applied per fee dimension.
+func updateUnitPrice(usage, target, price, min uint64) uint64 {
+	switch {
+	case usage > target:
+		delta := price/8 + 1
+		if target == 0 {
+			return price + delta
+		}
+		return price + (delta*(usage-target))/target
+	case usage < target:
+		delta := price / 8
+		if target == 0 || delta == 0 {
+			return price
+		}
+		next := price - (delta*(target-usage))/target
+		if next < min {
+			return min
+		}
+		return next
+	default:
+		return price
+	}
+}
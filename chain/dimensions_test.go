@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import "testing"
+
+func TestDimensionsRemaining(t *testing.T) {
+	max := Dimensions{100, 100, 100, 100, 100}
+	used := Dimensions{40, 100, 150, 0, 99}
+	got := used.Remaining(max)
+	want := Dimensions{60, 0, 0, 100, 1}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestTightestDimension(t *testing.T) {
+	max := Dimensions{100, 100, 100, 100, 100}
+	// StorageRead has the smallest remaining/max ratio (10%).
+	remaining := Dimensions{50, 20, 10, 80, 90}
+	if got := TightestDimension(remaining, max); got != StorageRead {
+		t.Fatalf("got %v, want %v", got, StorageRead)
+	}
+}
+
+func TestTightestDimensionIgnoresUncappedDimensions(t *testing.T) {
+	max := Dimensions{0, 0, 50, 0, 0}
+	remaining := Dimensions{0, 0, 25, 0, 0}
+	if got := TightestDimension(remaining, max); got != StorageRead {
+		t.Fatalf("got %v, want %v (the only dimension with a cap)", got, StorageRead)
+	}
+}
+
+func TestFeeRatePerUnit(t *testing.T) {
+	price := Dimensions{1, 2, 3, 4, 5}
+	d := Dimensions{10, 0, 0, 0, 20} // Bandwidth:10, StorageWrite:20
+	// Fee = 10*1 + 20*5 = 110; per unit of StorageWrite = 110/20 = 5.
+	if got := d.FeeRatePerUnit(price, StorageWrite); got != 5 {
+		t.Fatalf("got %d, want 5", got)
+	}
+	if got := d.FeeRatePerUnit(price, Compute); got != 0 {
+		t.Fatalf("got %d, want 0 for an unconsumed dimension", got)
+	}
+}
+
+func TestUpdateWindows(t *testing.T) {
+	usage := Dimensions{100, 50, 0, 0, 0}
+	target := Dimensions{50, 50, 50, 0, 0}
+	price := Dimensions{100, 100, 100, 1, 1}
+	min := Dimensions{1, 1, 1, 1, 1}
+
+	got := UpdateWindows(usage, target, price, min)
+	if got[Bandwidth] <= price[Bandwidth] {
+		t.Fatalf("expected Bandwidth price to rise above %d, got %d", price[Bandwidth], got[Bandwidth])
+	}
+	if got[Compute] != price[Compute] {
+		t.Fatalf("expected Compute price to stay at %d (usage == target), got %d", price[Compute], got[Compute])
+	}
+	if got[StorageRead] >= price[StorageRead] {
+		t.Fatalf("expected StorageRead price to fall below %d, got %d", price[StorageRead], got[StorageRead])
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/spacesvm/tdata"
+)
+
+var _ UnsignedTransaction = &ExportTx{}
+
+// ExportTx burns [Units] (or transfers ownership of [Prefix]) on this chain
+// and emits an unsigned Warp message that [DestinationChainID] can later
+// import via [ImportTx]. The message is signed by this chain's validator
+// set once it is accepted into a block.
+type ExportTx struct {
+	*BaseTx `serialize:"true" json:"baseTx"`
+
+	// DestinationChainID is the chain the exported value should be
+	// imported on.
+	DestinationChainID ids.ID `serialize:"true" json:"destinationChainID"`
+
+	// To is the recipient of [Units] (or the prefix, if set) on
+	// [DestinationChainID].
+	To common.Address `serialize:"true" json:"to"`
+
+	// Units are burned on this chain and credited to [To] once imported.
+	Units uint64 `serialize:"true" json:"units"`
+}
+
+// LoadUnits reports the resources [e] consumes per [FeeDimension]: a
+// balance burn or prefix deletion, plus the Warp message the validator set
+// must sign.
+func (e *ExportTx) LoadUnits(g *Genesis) Dimensions {
+	return Dimensions{
+		Compute:      g.BaseTxUnits,
+		StorageWrite: g.StorageKeyWriteUnits,
+	}
+}
+
+func (e *ExportTx) Execute(c *TransactionContext) error {
+	if bytes.Equal(e.To[:], zeroAddress[:]) {
+		return ErrNonActionable
+	}
+	if e.DestinationChainID == ids.Empty {
+		return ErrNonActionable
+	}
+	if len(e.Prefix) > 0 {
+		has, err := HasPrefix(c.Database, e.Prefix)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return ErrPrefixMissing
+		}
+		i, err := GetPrefixInfo(c.Database, e.Prefix)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(i.Owner[:], c.Sender[:]) {
+			return ErrUnauthorized
+		}
+		if err := DeletePrefixInfo(c.Database, e.Prefix); err != nil {
+			return err
+		}
+	} else {
+		if e.Units == 0 {
+			return ErrNonActionable
+		}
+		if _, err := ModifyBalance(c.Database, c.Sender, false, e.Units); err != nil {
+			return err
+		}
+	}
+	msg := &WarpPayload{
+		SourceChainID:      c.ChainID,
+		DestinationChainID: e.DestinationChainID,
+		Recipient:          e.To,
+		Units:              e.Units,
+		Prefix:             e.Prefix,
+		Nonce:              c.TxNonce,
+	}
+	return c.AddWarpMessage(msg)
+}
+
+func (e *ExportTx) Copy() UnsignedTransaction {
+	to := make([]byte, common.AddressLength)
+	copy(to, e.To[:])
+	prefix := make([]byte, len(e.Prefix))
+	copy(prefix, e.Prefix)
+	return &ExportTx{
+		BaseTx:             e.BaseTx.Copy(),
+		DestinationChainID: e.DestinationChainID,
+		To:                 common.BytesToAddress(to),
+		Units:              e.Units,
+	}
+}
+
+func (e *ExportTx) TypedData() tdata.TypedData {
+	return tdata.CreateTypedData(
+		e.Magic, Export,
+		[]tdata.Type{
+			{Name: "blockID", Type: "string"},
+			{Name: "price", Type: "uint64"},
+			{Name: "destinationChainID", Type: "string"},
+			{Name: "to", Type: "address"},
+			{Name: "units", Type: "uint64"},
+		},
+		tdata.TypedDataMessage{
+			"blockID":            e.BlockID.String(),
+			"price":              e.Price,
+			"destinationChainID": e.DestinationChainID.String(),
+			"to":                 e.To,
+			"units":              e.Units,
+		},
+	)
+}
@@ -4,21 +4,15 @@
 package chain
 
 import (
-	_ "embed"
-	"encoding/json"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	log "github.com/inconshreveable/log15"
-)
 
-type Airdrop struct {
-	// Address strings are hex-formatted common.Address
-	Address common.Address `serialize:"true" json:"address"`
-}
+	"github.com/ava-labs/spacesvm/auth"
+)
 
 type CustomAllocation struct {
 	// Address strings are hex-formatted common.Address
@@ -33,8 +27,14 @@ type Genesis struct {
 	BaseTxUnits uint64 `serialize:"true" json:"baseTxUnits"`
 
 	// SetTx params
-	ValueUnitSize uint64 `serialize:"true" json:"valueUnitSize"`
-	MaxValueSize  uint64 `serialize:"true" json:"maxValueSize"`
+	ValueUnitSize                     uint64 `serialize:"true" json:"valueUnitSize"`
+	MaxValueSize                      uint64 `serialize:"true" json:"maxValueSize"`
+	StorageKeyReadUnits               uint64 `serialize:"true" json:"storageKeyReadUnits"`
+	StorageValueReadUnits             uint64 `serialize:"true" json:"storageValueReadUnits"`
+	StorageKeyAllocateUnits           uint64 `serialize:"true" json:"storageKeyAllocateUnits"`
+	StorageValueAllocateUnitsPerChunk uint64 `serialize:"true" json:"storageValueAllocateUnitsPerChunk"`
+	StorageKeyWriteUnits              uint64 `serialize:"true" json:"storageKeyWriteUnits"`
+	StorageValueWriteUnitsPerChunk    uint64 `serialize:"true" json:"storageValueWriteUnitsPerChunk"`
 
 	// Claim Params
 	ClaimFeeMultiplier   uint64 `serialize:"true" json:"claimFeeMultiplier"`
@@ -56,16 +56,37 @@ type Genesis struct {
 	LotteryRewardDivisor   uint64 `serialize:"true" json:"lotteryRewardDivisor"`
 
 	// Fee Mechanism Params
-	LookbackWindow int64  `serialize:"true" json:"lookbackWindow"`
-	BlockTarget    int64  `serialize:"true" json:"blockTarget"`
-	TargetUnits    uint64 `serialize:"true" json:"targetUnits"`
-	MinPrice       uint64 `serialize:"true" json:"minPrice"`
-	MinBlockCost   uint64 `serialize:"true" json:"minBlockCost"`
+	//
+	// Each of [WindowTargetUnits], [MaxBlockUnits], and [MinUnitPrice] is a
+	// [Dimensions] vector (bandwidth, compute, storage read, storage
+	// allocate, storage write); the rolling window/price update in
+	// UpdateWindows is applied independently per dimension.
+	LookbackWindow    int64      `serialize:"true" json:"lookbackWindow"`
+	BlockTarget       int64      `serialize:"true" json:"blockTarget"`
+	WindowTargetUnits Dimensions `serialize:"true" json:"windowTargetUnits"`
+	MaxBlockUnits     Dimensions `serialize:"true" json:"maxBlockUnits"`
+	MinUnitPrice      Dimensions `serialize:"true" json:"minUnitPrice"`
+	MinBlockCost      uint64     `serialize:"true" json:"minBlockCost"`
 
 	// Allocations
 	CustomAllocation []*CustomAllocation `serialize:"true" json:"customAllocation"`
-	AirdropHash      string              `serialize:"true" json:"airdropHash"`
-	AirdropUnits     uint64              `serialize:"true" json:"airdropUnits"`
+	// AirdropMerkleRoot is the root of a keccak256 Merkle tree over sorted
+	// (address, units) leaves; nodes verify a ClaimAirdropTx's proof
+	// (which carries its own per-leaf Units) against this root instead of
+	// loading every allocation at genesis.
+	AirdropMerkleRoot common.Hash `serialize:"true" json:"airdropMerkleRoot"`
+
+	// Warp Params (cross-subnet Export/Import via Avalanche Warp Messaging)
+	Warp *WarpConfig `serialize:"true" json:"warp"`
+
+	// Auth Params
+	//
+	// EnabledAuthTypes restricts which auth.Type values a Transaction may
+	// be signed with; AuthComputeUnits overrides auth.Auth.ComputeUnits()
+	// per scheme so genesis can tune BLS/ed25519 pricing independent of
+	// the auth package's defaults.
+	EnabledAuthTypes []auth.Type          `serialize:"true" json:"enabledAuthTypes"`
+	AuthComputeUnits map[auth.Type]uint64 `serialize:"true" json:"authComputeUnits"`
 }
 
 func DefaultGenesis() *Genesis {
@@ -74,8 +95,14 @@ func DefaultGenesis() *Genesis {
 		BaseTxUnits: 10,
 
 		// SetTx params
-		ValueUnitSize: 256,             // 256B
-		MaxValueSize:  128 * units.KiB, // (500 Units)
+		ValueUnitSize:                     256,             // 256B
+		MaxValueSize:                      128 * units.KiB, // (512 Units)
+		StorageKeyReadUnits:               5,
+		StorageValueReadUnits:             2,
+		StorageKeyAllocateUnits:           20,
+		StorageValueAllocateUnitsPerChunk: 5,
+		StorageKeyWriteUnits:              10,
+		StorageValueWriteUnitsPerChunk:    5,
 
 		// Claim Params
 		ClaimFeeMultiplier:   5,
@@ -97,17 +124,57 @@ func DefaultGenesis() *Genesis {
 		LotteryRewardDivisor:   10,
 
 		// Fee Mechanism Params
-		LookbackWindow: 60,            // 60 Seconds
-		BlockTarget:    1,             // 1 Block per Second
-		TargetUnits:    10 * 512 * 60, // 5012 Units Per Block (~1.2MB of SetTx)
-		MinPrice:       1,             // (50 for easiest claim)
-		MinBlockCost:   0,             // Minimum Unit Overhead
+		LookbackWindow: 60, // 60 Seconds
+		BlockTarget:    1,  // 1 Block per Second
+		WindowTargetUnits: Dimensions{
+			10 * 512 * 60, // Bandwidth: 5120 Units Per Block (~1.2MB of SetTx)
+			10 * 512 * 60, // Compute
+			10 * 512 * 60, // StorageRead
+			10 * 512 * 60, // StorageAllocate
+			10 * 512 * 60, // StorageWrite
+		},
+		MaxBlockUnits: Dimensions{
+			10 * 512 * 60 * 2,
+			10 * 512 * 60 * 2,
+			10 * 512 * 60 * 2,
+			10 * 512 * 60 * 2,
+			10 * 512 * 60 * 2,
+		},
+		MinUnitPrice: Dimensions{1, 1, 1, 1, 1}, // (50 for easiest claim)
+		MinBlockCost: 0,                         // Minimum Unit Overhead
+
+		// Auth Params
+		EnabledAuthTypes: []auth.Type{auth.SECP256K1, auth.ED25519, auth.BLS},
 	}
 }
 
+// ComputeUnitsFor returns the cost of verifying an [auth.Auth] of type
+// [t], preferring a genesis override over the scheme's own default.
+// Called from BaseTx's auth-dispatch path (alongside [AuthTypeEnabled])
+// when charging a transaction's Compute units, the same way LoadUnits
+// does for every other resource a tx consumes.
+func (g *Genesis) ComputeUnitsFor(a auth.Auth, t auth.Type) uint64 {
+	if cu, ok := g.AuthComputeUnits[t]; ok {
+		return cu
+	}
+	return a.ComputeUnits()
+}
+
+// AuthTypeEnabled returns whether [t] is present in [EnabledAuthTypes].
+// BaseTx's auth-dispatch path rejects a tx signed with a disabled scheme
+// before it ever reaches [ComputeUnitsFor] or Verify.
+func (g *Genesis) AuthTypeEnabled(t auth.Type) bool {
+	for _, at := range g.EnabledAuthTypes {
+		if at == t {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Genesis) StatefulBlock() *StatefulBlock {
 	return &StatefulBlock{
-		Price: g.MinPrice,
+		Price: g.MinUnitPrice,
 		Cost:  g.MinBlockCost,
 	}
 }
@@ -116,34 +183,20 @@ func (g *Genesis) Verify() error {
 	if g.Magic == 0 {
 		return ErrInvalidMagic
 	}
-	return nil
-}
-
-func (g *Genesis) Load(db database.KeyValueWriter, airdropData []byte) error {
-	if len(g.AirdropHash) > 0 {
-		h := common.BytesToHash(crypto.Keccak256(airdropData)).Hex()
-		if g.AirdropHash != h {
-			return fmt.Errorf("expected standard allocation %s but got %s", g.AirdropHash, h)
-		}
-
-		standardAllocation := []*Airdrop{}
-		if err := json.Unmarshal(airdropData, &standardAllocation); err != nil {
-			return err
-		}
-
-		for _, alloc := range standardAllocation {
-			if err := SetBalance(db, alloc.Address, g.AirdropUnits); err != nil {
-				return fmt.Errorf("%w: addr=%s, bal=%d", err, alloc.Address, g.AirdropUnits)
-			}
+	if g.Warp != nil {
+		if g.Warp.QuorumNumerator == 0 || g.Warp.QuorumDenominator == 0 ||
+			g.Warp.QuorumNumerator > g.Warp.QuorumDenominator {
+			return ErrInvalidWarpQuorum
 		}
-		log.Debug(
-			"applied airdrop allocation",
-			"hash", h, "addrs", len(standardAllocation), "balance", g.AirdropUnits,
-		)
 	}
+	return nil
+}
 
-	// Do custom allocation last in case an address shows up in standard
-	// allocation
+// Load applies [CustomAllocation] to [db]. Airdrop allocations are no
+// longer written here: a ClaimAirdropTx credits them lazily, proven
+// against [AirdropMerkleRoot], so a node never has to ingest the full
+// allocation list at genesis.
+func (g *Genesis) Load(db database.KeyValueWriter) error {
 	for _, alloc := range g.CustomAllocation {
 		if err := SetBalance(db, alloc.Address, alloc.Balance); err != nil {
 			return fmt.Errorf("%w: addr=%s, bal=%d", err, alloc.Address, alloc.Balance)
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import "testing"
+
+func TestGenesisVerifyRejectsZeroValueWarpQuorum(t *testing.T) {
+	tests := []struct {
+		name string
+		warp *WarpConfig
+		ok   bool
+	}{
+		{
+			name: "nil Warp is not subject to the quorum check",
+			warp: nil,
+			ok:   true,
+		},
+		{
+			name: "zero-value WarpConfig satisfies 0 < 0 and must be rejected",
+			warp: &WarpConfig{},
+			ok:   false,
+		},
+		{
+			name: "zero QuorumNumerator alone must be rejected",
+			warp: &WarpConfig{QuorumNumerator: 0, QuorumDenominator: 100},
+			ok:   false,
+		},
+		{
+			name: "QuorumNumerator greater than QuorumDenominator must be rejected",
+			warp: &WarpConfig{QuorumNumerator: 101, QuorumDenominator: 100},
+			ok:   false,
+		},
+		{
+			name: "a sane quorum is accepted",
+			warp: &WarpConfig{QuorumNumerator: 67, QuorumDenominator: 100},
+			ok:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := DefaultGenesis()
+			g.Magic = 1
+			g.Warp = tt.warp
+			err := g.Verify()
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/spacesvm/tdata"
+)
+
+var _ UnsignedTransaction = &ImportTx{}
+
+// ImportTx carries a signed Warp message produced by an [ExportTx] on
+// another chain and credits the local recipient. [VerifyWarpMessage] is
+// run against the source chain's validator set at [Message.PChainHeight]
+// as a block-verification predicate before [Execute] is ever called;
+// [Execute] only needs to guard against replay and apply the
+// balance/prefix change.
+type ImportTx struct {
+	*BaseTx `serialize:"true" json:"baseTx"`
+
+	// Message is the signed Warp message exported from SourceChainID.
+	Message *WarpMessage `serialize:"true" json:"message"`
+}
+
+// LoadUnits reports the resources [i] consumes per [FeeDimension]: BLS
+// signature verification (Compute), the replay-protection write, and the
+// balance/prefix credit write.
+func (i *ImportTx) LoadUnits(g *Genesis) Dimensions {
+	var warpFee uint64
+	if g.Warp != nil {
+		warpFee = g.Warp.BaseImportFee
+	}
+	return Dimensions{
+		Compute:      g.BaseTxUnits + warpFee,
+		StorageWrite: 2 * g.StorageKeyWriteUnits,
+	}
+}
+
+func (i *ImportTx) Execute(c *TransactionContext) error {
+	if i.Message == nil {
+		return ErrNonActionable
+	}
+	payload := i.Message.Payload
+	if payload.DestinationChainID != c.ChainID {
+		return ErrWrongChainID
+	}
+	if !c.WarpConfig.AllowsSource(payload.SourceChainID) {
+		return ErrUntrustedSource
+	}
+
+	imported, err := HasImport(c.Database, payload.SourceChainID, payload.Nonce)
+	if err != nil {
+		return err
+	}
+	if imported {
+		return ErrDuplicateImport
+	}
+	if err := PutImport(c.Database, payload.SourceChainID, payload.Nonce); err != nil {
+		return err
+	}
+
+	if len(payload.Prefix) > 0 {
+		return SetPrefixInfo(c.Database, payload.Prefix, &PrefixInfo{Owner: payload.Recipient})
+	}
+	if payload.Units == 0 {
+		return ErrNonActionable
+	}
+	_, err = ModifyBalance(c.Database, payload.Recipient, true, payload.Units)
+	return err
+}
+
+func (i *ImportTx) Copy() UnsignedTransaction {
+	var msg *WarpMessage
+	if i.Message != nil {
+		m := *i.Message
+		msg = &m
+	}
+	return &ImportTx{
+		BaseTx:  i.BaseTx.Copy(),
+		Message: msg,
+	}
+}
+
+func (i *ImportTx) TypedData() tdata.TypedData {
+	var srcID ids.ID
+	var nonce uint64
+	if i.Message != nil {
+		srcID = i.Message.Payload.SourceChainID
+		nonce = i.Message.Payload.Nonce
+	}
+	return tdata.CreateTypedData(
+		i.Magic, Import,
+		[]tdata.Type{
+			{Name: "blockID", Type: "string"},
+			{Name: "price", Type: "uint64"},
+			{Name: "sourceChainID", Type: "string"},
+			{Name: "nonce", Type: "uint64"},
+		},
+		tdata.TypedDataMessage{
+			"blockID":       i.BlockID.String(),
+			"price":         i.Price,
+			"sourceChainID": srcID.String(),
+			"nonce":         nonce,
+		},
+	)
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// AirdropLeaf is a single (address, units) allocation hashed into the
+// airdrop Merkle tree. Leaves are sorted by address before the tree is
+// built so the same allocation set always produces the same root
+// regardless of input order.
+type AirdropLeaf struct {
+	Address common.Address `json:"address"`
+	Units   uint64         `json:"units"`
+}
+
+// Hash returns the keccak256 leaf hash of [l], matching the encoding used
+// by OpenZeppelin's MerkleProof (abi.encodePacked(index, address, units)).
+func (l *AirdropLeaf) Hash(index uint64) common.Hash {
+	b := make([]byte, 8+common.AddressLength+8)
+	putUint64(b, index)
+	copy(b[8:], l.Address[:])
+	putUint64(b[8+common.AddressLength:], l.Units)
+	return common.BytesToHash(crypto.Keccak256(b))
+}
+
+func putUint64(b []byte, v uint64) {
+	for i := 7; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+}
+
+// BuildAirdropMerkleTree hashes [leaves] (indexed by their position after
+// sorting by address) into a keccak256 Merkle tree, duplicating the last
+// node of any odd layer (the OpenZeppelin convention) rather than
+// promoting it unchanged. It returns the layers from leaves (layer 0) to
+// root (last layer, a single node), so callers can derive proofs for any
+// leaf without rebuilding the tree.
+func BuildAirdropMerkleTree(leaves []*AirdropLeaf) [][]common.Hash {
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i].Address[:], leaves[j].Address[:]) < 0
+	})
+
+	layer := make([]common.Hash, len(leaves))
+	for i, l := range leaves {
+		layer[i] = l.Hash(uint64(i))
+	}
+	layers := [][]common.Hash{layer}
+
+	for len(layer) > 1 {
+		next := make([]common.Hash, 0, (len(layer)+1)/2)
+		for i := 0; i < len(layer); i += 2 {
+			if i+1 == len(layer) {
+				next = append(next, hashPair(layer[i], layer[i])) // dup-last
+				break
+			}
+			next = append(next, hashPair(layer[i], layer[i+1]))
+		}
+		layers = append(layers, next)
+		layer = next
+	}
+	return layers
+}
+
+// AirdropMerkleRoot returns the root of the tree built from [leaves], or
+// the zero hash if there are no leaves.
+func AirdropMerkleRoot(leaves []*AirdropLeaf) common.Hash {
+	layers := BuildAirdropMerkleTree(leaves)
+	last := layers[len(layers)-1]
+	if len(last) == 0 {
+		return common.Hash{}
+	}
+	return last[0]
+}
+
+// AirdropMerkleProof returns the sibling hashes needed to verify
+// leaves[index] against the tree's root, in bottom-up order.
+func AirdropMerkleProof(leaves []*AirdropLeaf, index int) [][]byte {
+	layers := BuildAirdropMerkleTree(leaves)
+	proof := make([][]byte, 0, len(layers)-1)
+	for _, layer := range layers[:len(layers)-1] {
+		siblingIndex := index ^ 1
+		if siblingIndex >= len(layer) {
+			siblingIndex = index // dup-last
+		}
+		h := layer[siblingIndex]
+		proof = append(proof, h[:])
+		index /= 2
+	}
+	return proof
+}
+
+// VerifyAirdropMerkleProof checks that leaf (at [index], with [units])
+// hashes, combined with [proof], to [root].
+func VerifyAirdropMerkleProof(
+	root common.Hash,
+	proof [][]byte,
+	index uint64,
+	addr common.Address,
+	units uint64,
+) bool {
+	computed := (&AirdropLeaf{Address: addr, Units: units}).Hash(index)
+	for _, sib := range proof {
+		computed = hashPair(computed, common.BytesToHash(sib))
+	}
+	return computed == root
+}
+
+func hashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return common.BytesToHash(crypto.Keccak256(append(a[:], b[:]...)))
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestAirdropMerkleProofNonUniformUnits(t *testing.T) {
+	leaves := []*AirdropLeaf{
+		{Address: common.HexToAddress("0x1"), Units: 100},
+		{Address: common.HexToAddress("0x2"), Units: 250},
+		{Address: common.HexToAddress("0x3"), Units: 1},
+		{Address: common.HexToAddress("0x4"), Units: 900000},
+		{Address: common.HexToAddress("0x5"), Units: 7},
+	}
+	root := AirdropMerkleRoot(leaves)
+
+	sorted := make([]*AirdropLeaf, len(leaves))
+	copy(sorted, leaves)
+	BuildAirdropMerkleTree(sorted)
+
+	for i, l := range sorted {
+		proof := AirdropMerkleProof(sorted, i)
+		if !VerifyAirdropMerkleProof(root, proof, uint64(i), l.Address, l.Units) {
+			t.Fatalf("leaf %d (addr=%s units=%d) failed to verify with its own units", i, l.Address, l.Units)
+		}
+		// A flat/wrong amount must not verify, even if it happens to
+		// match some other leaf's units: this is the exact bug where
+		// verification recomputed the hash with a single genesis-wide
+		// rate instead of the per-leaf amount committed into the tree.
+		if VerifyAirdropMerkleProof(root, proof, uint64(i), l.Address, l.Units+1) {
+			t.Fatalf("leaf %d verified with the wrong units", i)
+		}
+	}
+}
+
+func TestAirdropMerkleProofWrongIndexFails(t *testing.T) {
+	leaves := []*AirdropLeaf{
+		{Address: common.HexToAddress("0x1"), Units: 100},
+		{Address: common.HexToAddress("0x2"), Units: 200},
+	}
+	root := AirdropMerkleRoot(leaves)
+
+	sorted := make([]*AirdropLeaf, len(leaves))
+	copy(sorted, leaves)
+	BuildAirdropMerkleTree(sorted)
+
+	proof := AirdropMerkleProof(sorted, 0)
+	if VerifyAirdropMerkleProof(root, proof, 1, sorted[0].Address, sorted[0].Units) {
+		t.Fatal("proof for index 0 verified against index 1")
+	}
+}
+
+func TestAirdropMerkleRootOddLeafCount(t *testing.T) {
+	leaves := []*AirdropLeaf{
+		{Address: common.HexToAddress("0x1"), Units: 1},
+		{Address: common.HexToAddress("0x2"), Units: 2},
+		{Address: common.HexToAddress("0x3"), Units: 3},
+	}
+	root := AirdropMerkleRoot(leaves)
+
+	sorted := make([]*AirdropLeaf, len(leaves))
+	copy(sorted, leaves)
+	BuildAirdropMerkleTree(sorted)
+
+	for i, l := range sorted {
+		proof := AirdropMerkleProof(sorted, i)
+		if !VerifyAirdropMerkleProof(root, proof, uint64(i), l.Address, l.Units) {
+			t.Fatalf("leaf %d failed to verify in an odd-sized (dup-last) tree", i)
+		}
+	}
+}
@@ -22,6 +22,17 @@ type TransferTx struct {
 	Units uint64 `serialize:"true" json:"units"`
 }
 
+// LoadUnits reports the resources [t] consumes per [FeeDimension]: a
+// transfer only moves an existing balance entry, so it pays bandwidth (the
+// serialized tx size, accounted for by BaseTx) and a single storage write
+// for the recipient's balance. Execute also writes the sender's debited
+// balance; that write is not charged separately.
+func (t *TransferTx) LoadUnits(g *Genesis) Dimensions {
+	return Dimensions{
+		StorageWrite: g.StorageKeyWriteUnits + g.StorageValueWriteUnitsPerChunk,
+	}
+}
+
 func (t *TransferTx) Execute(c *TransactionContext) error {
 	// Must transfer to someone
 	if bytes.Equal(t.To[:], zeroAddress[:]) {
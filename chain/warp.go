@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const importPrefix = 0x4 // shares the top-level key prefix space with balances/prefixes
+
+// WarpPayload is the application-level payload an [ExportTx] asks the
+// validator set to sign. It is embedded in an AvalancheGo
+// warp.UnsignedMessage before aggregation.
+type WarpPayload struct {
+	SourceChainID      ids.ID         `serialize:"true" json:"sourceChainID"`
+	DestinationChainID ids.ID         `serialize:"true" json:"destinationChainID"`
+	Recipient          common.Address `serialize:"true" json:"recipient"`
+	Units              uint64         `serialize:"true" json:"units"`
+	Prefix             []byte         `serialize:"true" json:"prefix"`
+	Nonce              uint64         `serialize:"true" json:"nonce"`
+}
+
+// WarpMessage is a [WarpPayload] plus the aggregate BLS signature and
+// P-Chain height the signers were sampled at, as produced by the
+// AvalancheGo warp signature aggregator.
+type WarpMessage struct {
+	Payload      *WarpPayload `serialize:"true" json:"payload"`
+	PChainHeight uint64       `serialize:"true" json:"pChainHeight"`
+	Signature    []byte       `serialize:"true" json:"signature"`
+
+	// Signers is a bitset, one bit per index into the validator set
+	// returned for SourceChainID at PChainHeight (bit i set means that
+	// validator contributed to the aggregate Signature), so a verifier
+	// can reconstruct which public keys to aggregate and sum the signing
+	// weight against the source chain's quorum requirement.
+	Signers []byte `serialize:"true" json:"signers"`
+}
+
+// WarpConfig controls which source chains an ImportTx may reference and
+// the quorum required of their validator set.
+type WarpConfig struct {
+	// SourceChainAllowlist restricts ImportTx to messages exported from
+	// one of these chains. An empty allowlist rejects all imports.
+	SourceChainAllowlist []ids.ID `serialize:"true" json:"sourceChainAllowlist"`
+
+	// QuorumNumerator/QuorumDenominator set the fraction of aggregate
+	// validator stake weight that must have signed a Warp message for it
+	// to be accepted (e.g. 67/100).
+	QuorumNumerator   uint64 `serialize:"true" json:"quorumNumerator"`
+	QuorumDenominator uint64 `serialize:"true" json:"quorumDenominator"`
+
+	// BaseImportFee is charged (in addition to normal tx units) to cover
+	// the cost of BLS signature verification.
+	BaseImportFee uint64 `serialize:"true" json:"baseImportFee"`
+}
+
+// AllowsSource returns true if [chainID] is present in the allowlist.
+func (w *WarpConfig) AllowsSource(chainID ids.ID) bool {
+	for _, id := range w.SourceChainAllowlist {
+		if id == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+func importKey(sourceChainID ids.ID, nonce uint64) []byte {
+	k := make([]byte, 1+ids.IDLen+8)
+	k[0] = importPrefix
+	copy(k[1:], sourceChainID[:])
+	binary.BigEndian.PutUint64(k[1+ids.IDLen:], nonce)
+	return k
+}
+
+// HasImport returns whether [nonce] from [sourceChainID] has already been
+// imported, to protect against replaying the same Warp message.
+func HasImport(db database.KeyValueReader, sourceChainID ids.ID, nonce uint64) (bool, error) {
+	return db.Has(importKey(sourceChainID, nonce))
+}
+
+// PutImport persists [nonce] from [sourceChainID] as imported.
+func PutImport(db database.KeyValueWriter, sourceChainID ids.ID, nonce uint64) error {
+	return db.Put(importKey(sourceChainID, nonce), nil)
+}
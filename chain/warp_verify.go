@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// WarpValidator is a single member of the source chain's validator set at
+// the P-Chain height a [WarpMessage] claims to be signed at, as sourced
+// from platform chain validator state.
+type WarpValidator struct {
+	PublicKey *bls.PublicKey
+	Weight    uint64
+}
+
+// VerifyWarpMessage checks that [msg].Signature is a valid BLS aggregate
+// signature over [msg].Payload, contributed by the subset of [validators]
+// marked in [msg].Signers, and that their combined weight meets the
+// quorum [cfg] requires. It is run as a block-verification predicate
+// before an ImportTx carrying [msg] is ever accepted into a block;
+// [ImportTx.Execute] assumes this has already passed and only handles
+// replay protection and the resulting balance/prefix change.
+func VerifyWarpMessage(msg *WarpMessage, validators []*WarpValidator, cfg *WarpConfig) error {
+	if len(validators) == 0 {
+		return ErrNoWarpValidators
+	}
+	if cfg == nil {
+		return ErrUntrustedSource
+	}
+
+	signerIdxs, err := signerIndices(msg.Signers, len(validators))
+	if err != nil {
+		return err
+	}
+	if len(signerIdxs) == 0 {
+		return ErrInvalidWarpSignature
+	}
+
+	var totalWeight, signedWeight uint64
+	pks := make([]*bls.PublicKey, 0, len(signerIdxs))
+	for _, v := range validators {
+		totalWeight += v.Weight
+	}
+	for _, idx := range signerIdxs {
+		v := validators[idx]
+		signedWeight += v.Weight
+		pks = append(pks, v.PublicKey)
+	}
+
+	// QuorumNumerator/QuorumDenominator gate the fraction of total
+	// validator weight that must have signed; cross-multiply to avoid
+	// floating point.
+	if signedWeight*cfg.QuorumDenominator < totalWeight*cfg.QuorumNumerator {
+		return ErrWarpQuorumNotMet
+	}
+
+	aggPK, err := bls.AggregatePublicKeys(pks)
+	if err != nil {
+		return err
+	}
+	sig, err := bls.SignatureFromBytes(msg.Signature)
+	if err != nil {
+		return ErrInvalidWarpSignature
+	}
+	if !bls.Verify(aggPK, sig, warpUnsignedBytes(msg.Payload, msg.PChainHeight)) {
+		return ErrInvalidWarpSignature
+	}
+	return nil
+}
+
+// signerIndices returns the set bit positions of [signers], the bitset
+// naming which of [numValidators] validators contributed to the aggregate
+// signature (bit i, MSB-first within each byte, corresponds to
+// validators[i]).
+func signerIndices(signers []byte, numValidators int) ([]int, error) {
+	if len(signers) != (numValidators+7)/8 {
+		return nil, ErrInvalidWarpSignature
+	}
+	idxs := make([]int, 0, numValidators)
+	for i := 0; i < numValidators; i++ {
+		if signers[i/8]&(1<<(7-uint(i%8))) != 0 {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs, nil
+}
+
+// warpUnsignedBytes is the canonical encoding of [payload] at
+// [pChainHeight] that validators sign and verifiers recompute; it must
+// match byte-for-byte on both sides, so every field except Prefix is
+// encoded at a fixed width rather than relying on the tx codec used for
+// signed transactions. Prefix is appended as raw bytes with no length
+// prefix; it is the only variable-length field.
+func warpUnsignedBytes(payload *WarpPayload, pChainHeight uint64) []byte {
+	b := make([]byte, 0, ids.IDLen*2+common.AddressLength+8+len(payload.Prefix)+8+8)
+	b = append(b, payload.SourceChainID[:]...)
+	b = append(b, payload.DestinationChainID[:]...)
+	b = append(b, payload.Recipient[:]...)
+	b = appendUint64(b, payload.Units)
+	b = append(b, payload.Prefix...)
+	b = appendUint64(b, payload.Nonce)
+	b = appendUint64(b, pChainHeight)
+	return b
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	u := make([]byte, 8)
+	putUint64(u, v)
+	return append(b, u...)
+}
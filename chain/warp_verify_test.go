@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSignerIndices(t *testing.T) {
+	tests := []struct {
+		name          string
+		signers       []byte
+		numValidators int
+		want          []int
+	}{
+		{
+			name:          "single byte, every validator signed",
+			signers:       []byte{0b11111000},
+			numValidators: 5,
+			want:          []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:          "single byte, sparse",
+			signers:       []byte{0b10100000},
+			numValidators: 5,
+			want:          []int{0, 2},
+		},
+		{
+			name:          "spans two bytes",
+			signers:       []byte{0b00000001, 0b10000000},
+			numValidators: 9,
+			want:          []int{7, 8},
+		},
+		{
+			name:          "none signed",
+			signers:       []byte{0b00000000},
+			numValidators: 5,
+			want:          []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signerIndices(tt.signers, tt.numValidators)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) == 0 {
+				got = []int{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignerIndicesWrongLength(t *testing.T) {
+	if _, err := signerIndices([]byte{0x00}, 9); err == nil {
+		t.Fatal("expected an error for a bitset too short for numValidators")
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/quarkvm/chain"
+)
+
+// AirdropAllocation is a single entry of the JSON file used to build an
+// airdrop Merkle tree offline (the same shape genesis tooling reads to
+// compute [chain.Genesis.AirdropMerkleRoot]).
+type AirdropAllocation struct {
+	Address common.Address `json:"address"`
+	Units   uint64         `json:"units"`
+}
+
+// AirdropProof is the proof material a holder needs to submit a
+// ClaimAirdropTx for their allocation.
+type AirdropProof struct {
+	Index uint64   `json:"index"`
+	Units uint64   `json:"units"`
+	Proof [][]byte `json:"proof"`
+}
+
+// GenerateAirdropProof reads the JSON allocation list at [allocationFile],
+// rebuilds the same Merkle tree genesis tooling used to compute
+// AirdropMerkleRoot, and returns the proof for [addr].
+func GenerateAirdropProof(allocationFile string, addr common.Address) (*AirdropProof, error) {
+	raw, err := os.ReadFile(allocationFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var allocations []*AirdropAllocation
+	if err := json.Unmarshal(raw, &allocations); err != nil {
+		return nil, err
+	}
+
+	leaves := make([]*chain.AirdropLeaf, len(allocations))
+	for i, a := range allocations {
+		leaves[i] = &chain.AirdropLeaf{Address: a.Address, Units: a.Units}
+	}
+
+	// The tree is built in sorted order inside BuildAirdropMerkleTree, so
+	// find the sorted index here (not the file's input order) before
+	// asking for a proof at that position.
+	sorted := make([]*chain.AirdropLeaf, len(leaves))
+	copy(sorted, leaves)
+	chain.BuildAirdropMerkleTree(sorted) // sorts [sorted] in place as a side effect
+
+	index := -1
+	var units uint64
+	for i, l := range sorted {
+		if l.Address == addr {
+			index = i
+			units = l.Units
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("address %s not found in %s", addr, allocationFile)
+	}
+
+	proof := chain.AirdropMerkleProof(sorted, index)
+	return &AirdropProof{Index: uint64(index), Units: units, Proof: proof}, nil
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	log "github.com/inconshreveable/log15"
+
+	"github.com/ava-labs/quarkvm/chain"
+	"github.com/ava-labs/quarkvm/pow"
+	"github.com/ava-labs/quarkvm/vm"
+)
+
+// Client wraps the subset of the VM's JSON-RPC API that mining needs, so
+// [Mine] can be unit tested against a fake implementation instead of a
+// live rpc.EndpointRequester.
+type Client interface {
+	CurrBlock(ctx context.Context) (ids.ID, error)
+	ValidBlockID(ctx context.Context, blkID ids.ID) (bool, error)
+	DifficultyEstimate(ctx context.Context) (uint64, error)
+	SubscribeBlocks(ctx context.Context) (<-chan ids.ID, error)
+}
+
+// requesterClient adapts an rpc.EndpointRequester (what every quark-cli
+// subcommand already constructs) to the [Client] interface.
+type requesterClient struct {
+	requester rpc.EndpointRequester
+}
+
+// NewRequesterClient wraps [requester] as a [Client].
+func NewRequesterClient(requester rpc.EndpointRequester) Client {
+	return &requesterClient{requester: requester}
+}
+
+func (c *requesterClient) CurrBlock(ctx context.Context) (ids.ID, error) {
+	resp := new(vm.CurrBlockReply)
+	if err := c.requester.SendRequest("currBlock", &vm.CurrBlockArgs{}, resp); err != nil {
+		return ids.ID{}, err
+	}
+	return resp.BlockID, nil
+}
+
+func (c *requesterClient) ValidBlockID(ctx context.Context, blkID ids.ID) (bool, error) {
+	resp := new(vm.ValidBlockIDReply)
+	if err := c.requester.SendRequest("validBlockID", &vm.ValidBlockIDArgs{BlockID: blkID}, resp); err != nil {
+		return false, err
+	}
+	return resp.Valid, nil
+}
+
+func (c *requesterClient) DifficultyEstimate(ctx context.Context) (uint64, error) {
+	resp := new(vm.DifficultyEstimateReply)
+	if err := c.requester.SendRequest("difficultyEstimate", &vm.DifficultyEstimateArgs{}, resp); err != nil {
+		return 0, err
+	}
+	return resp.Difficulty, nil
+}
+
+// SubscribeBlocks polls "currBlock" every 100ms (waiting a second after a
+// failed poll) and streams each block ID that differs from the last one
+// sent, so [Mine] can react to a block rotation without paying a
+// currBlock/validBlockID round-trip on every graffiti attempt. It does not
+// use a VM-side subscription endpoint.
+func (c *requesterClient) SubscribeBlocks(ctx context.Context) (<-chan ids.ID, error) {
+	ch := make(chan ids.ID)
+	go func() {
+		defer close(ch)
+		var last ids.ID
+		for ctx.Err() == nil {
+			resp := new(vm.CurrBlockReply)
+			if err := c.requester.SendRequest("currBlock", &vm.CurrBlockArgs{}, resp); err != nil {
+				log.Debug("subscribeBlocks poll failed", "err", err)
+				time.Sleep(time.Second)
+				continue
+			}
+			if resp.BlockID != last {
+				last = resp.BlockID
+				select {
+				case ch <- last:
+				case <-ctx.Done():
+					return
+				}
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}()
+	return ch, nil
+}
+
+// MineOptions bounds how long/hard [Mine] is allowed to work.
+type MineOptions struct {
+	// MaxGraffiti caps the graffiti values tried against one block (0
+	// means unbounded): worker w stops once its graffiti reaches
+	// w+MaxGraffiti, so about MaxGraffiti values are tried in total across
+	// all workers. Mine then waits for the next block or for ctx to end.
+	MaxGraffiti uint64
+	// Deadline, if non-zero, stops mining (returning ctx.Err()) once
+	// reached, independent of the caller's context.
+	Deadline time.Time
+	// HashRate, if non-nil, is updated with the aggregate hashes/sec
+	// across all workers approximately once per second.
+	HashRate *uint64
+}
+
+// Mine spawns runtime.NumCPU() workers that each search a disjoint
+// graffiti range for a value that meets the VM's current difficulty
+// estimate, restarting whenever [cli] reports a new block via
+// SubscribeBlocks. It replaces the single-threaded, per-iteration-RPC
+// loop duplicated across quark-cli's claim/set/transfer/lifeline
+// commands.
+func Mine(ctx context.Context, cli Client, utx chain.UnsignedTransaction, opts MineOptions) (chain.UnsignedTransaction, error) {
+	if !opts.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, opts.Deadline)
+		defer cancel()
+	}
+
+	cbID, err := cli.CurrBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := cli.SubscribeBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		utx.SetBlockID(cbID)
+		mtx, nextID, err := mineBlock(ctx, cli, utx, cbID, blocks, &opts)
+		if err != nil {
+			return nil, err
+		}
+		if mtx != nil {
+			return mtx, nil
+		}
+		// The block rotated (to nextID) without anyone finding a
+		// solution; restart against it.
+		cbID = nextID
+	}
+}
+
+// mineBlock races runtime.NumCPU() workers, each iterating a disjoint
+// graffiti range, against [blockID]. If [blocks] reports [blockID] is no
+// longer current before anyone finds a solution, it returns (nil,
+// newBlockID, nil) so the caller can restart against the new block.
+func mineBlock(
+	ctx context.Context,
+	cli Client,
+	utx chain.UnsignedTransaction,
+	blockID ids.ID,
+	blocks <-chan ids.ID,
+	opts *MineOptions,
+) (chain.UnsignedTransaction, ids.ID, error) {
+	workers := runtime.NumCPU()
+	if workers < 1 {
+		workers = 1
+	}
+
+	// solved flips once any worker meets the difficulty target, so the
+	// rest stop trying further graffiti values for this block.
+	var solved atomic.Bool
+	var hashes atomic.Uint64
+	found := make(chan chain.UnsignedTransaction, 1)
+	errs := make(chan error, workers)
+	rotated := make(chan ids.ID, 1)
+
+	workerCtx, cancelWorkers := context.WithCancel(ctx)
+	defer cancelWorkers()
+
+	est, err := cli.DifficultyEstimate(ctx)
+	if err != nil {
+		return nil, ids.ID{}, err
+	}
+
+	for w := 0; w < workers; w++ {
+		go func(start uint64) {
+			graffiti := start
+			for workerCtx.Err() == nil && !solved.Load() {
+				if opts.MaxGraffiti > 0 && graffiti-start >= opts.MaxGraffiti {
+					return
+				}
+				wtx := utx.Copy()
+				wtx.SetBlockID(blockID)
+				wtx.SetGraffiti(graffiti)
+				b, err := chain.UnsignedBytes(wtx)
+				if err != nil {
+					errs <- err
+					return
+				}
+				hashes.Add(1)
+				if pow.Difficulty(b) >= est {
+					if solved.CompareAndSwap(false, true) {
+						found <- wtx
+					}
+					return
+				}
+				graffiti += uint64(workers)
+			}
+		}(uint64(w))
+	}
+
+	if opts.HashRate != nil {
+		go reportHashRate(workerCtx, &hashes, opts.HashRate)
+	}
+
+	go func() {
+		if newID, ok := waitForRotation(workerCtx, blocks, blockID); ok {
+			rotated <- newID
+		}
+	}()
+
+	select {
+	case mtx := <-found:
+		return mtx, ids.ID{}, nil
+	case err := <-errs:
+		return nil, ids.ID{}, err
+	case newID := <-rotated:
+		return nil, newID, nil
+	case <-ctx.Done():
+		return nil, ids.ID{}, ctx.Err()
+	}
+}
+
+// waitForRotation consumes [blocks] until it reports an ID different from
+// [blockID] (returning that ID and true), [workerCtx] is cancelled, or
+// [blocks] is closed (both the latter return false). SubscribeBlocks
+// always reports the chain's current block as its first message, which is
+// just [blockID] restated, so a single receive is not enough to detect a
+// real rotation — this keeps consuming until one actually happens.
+func waitForRotation(workerCtx context.Context, blocks <-chan ids.ID, blockID ids.ID) (ids.ID, bool) {
+	for {
+		select {
+		case newID, ok := <-blocks:
+			if !ok {
+				return ids.ID{}, false
+			}
+			if newID != blockID {
+				return newID, true
+			}
+		case <-workerCtx.Done():
+			return ids.ID{}, false
+		}
+	}
+}
+
+func reportHashRate(ctx context.Context, hashes *atomic.Uint64, rate *uint64) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			atomic.StoreUint64(rate, hashes.Swap(0))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
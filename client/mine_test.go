@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// TestWaitForRotationSkipsRestatedBlock guards against the bug where
+// mineBlock's rotation watcher treated SubscribeBlocks's first message (a
+// restatement of the already-current block) as a rotation and returned
+// immediately, leaving workers hashing against a stale block forever.
+func TestWaitForRotationSkipsRestatedBlock(t *testing.T) {
+	blockID := ids.GenerateTestID()
+	nextID := ids.GenerateTestID()
+
+	blocks := make(chan ids.ID, 2)
+	blocks <- blockID // restated current block; must be ignored
+	blocks <- nextID  // the real rotation
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gotID, ok := waitForRotation(ctx, blocks, blockID)
+	if !ok {
+		t.Fatal("expected waitForRotation to report a rotation")
+	}
+	if gotID != nextID {
+		t.Fatalf("got block %s, want %s", gotID, nextID)
+	}
+}
+
+// TestWaitForRotationNoRotation checks that waitForRotation keeps waiting
+// (rather than firing on the restated current block) until the context is
+// cancelled, when no real rotation ever arrives.
+func TestWaitForRotationNoRotation(t *testing.T) {
+	blockID := ids.GenerateTestID()
+
+	blocks := make(chan ids.ID, 1)
+	blocks <- blockID // restated current block; must be ignored
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, ok := waitForRotation(ctx, blocks, blockID); ok {
+		t.Fatal("expected waitForRotation to report no rotation")
+	}
+}
+
+// TestWaitForRotationClosedChannel checks that a closed [blocks] channel is
+// treated the same as the context being done, not as a rotation.
+func TestWaitForRotationClosedChannel(t *testing.T) {
+	blockID := ids.GenerateTestID()
+
+	blocks := make(chan ids.ID)
+	close(blocks)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, ok := waitForRotation(ctx, blocks, blockID); ok {
+		t.Fatal("expected waitForRotation to report no rotation on closed channel")
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package airdropclaim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/quarkvm/auth"
+	"github.com/ava-labs/quarkvm/chain"
+	"github.com/ava-labs/quarkvm/client"
+	"github.com/ava-labs/quarkvm/cmd/quarkcli/create"
+	"github.com/ava-labs/quarkvm/vm"
+)
+
+func init() {
+	cobra.EnablePrefixMatching = true
+}
+
+var (
+	privateKeyFile string
+	url            string
+	endpoint       string
+	requestTimeout time.Duration
+	proofFile      string
+	authType       string
+)
+
+// NewCommand implements "quark-cli airdrop-claim" command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "airdrop-claim [options] <index>",
+		Short: "Claims the airdrop allocation at the given Merkle tree index",
+		Long: `
+Claims the airdrop allocation at <index> by submitting a "ClaimAirdropTx"
+with a Merkle proof against the genesis AirdropMerkleRoot.
+
+# Claims the allocation whose proof (a JSON-encoded "client.AirdropProof",
+# as returned by "client.GenerateAirdropProof") is in the proof file
+$ quark-cli airdrop-claim 42 --proof-file=.quark-cli-airdrop-proof
+<<COMMENT
+success
+COMMENT
+
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: airdropClaimFunc,
+	}
+	cmd.PersistentFlags().StringVar(
+		&privateKeyFile,
+		"private-key-file",
+		".quark-cli-pk",
+		"private key file path",
+	)
+	cmd.PersistentFlags().StringVar(
+		&url,
+		"url",
+		"http://127.0.0.1:9650",
+		"RPC URL for VM",
+	)
+	cmd.PersistentFlags().StringVar(
+		&endpoint,
+		"endpoint",
+		"",
+		"RPC endpoint for VM",
+	)
+	cmd.PersistentFlags().DurationVar(
+		&requestTimeout,
+		"request-timeout",
+		30*time.Second,
+		"set it to 0 to not wait for transaction confirmation",
+	)
+	cmd.PersistentFlags().StringVar(
+		&proofFile,
+		"proof-file",
+		"",
+		"path to the JSON-encoded client.AirdropProof (as returned by client.GenerateAirdropProof)",
+	)
+	cmd.PersistentFlags().StringVar(
+		&authType,
+		"auth-type",
+		auth.SECP256K1.String(),
+		"auth scheme to sign the tx with ('secp256k1', 'ed25519', or 'bls')",
+	)
+	return cmd
+}
+
+func airdropClaimFunc(cmd *cobra.Command, args []string) error {
+	signer, err := create.LoadSigner(authType, privateKeyFile)
+	if err != nil {
+		return err
+	}
+
+	index, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid index %q: %w", args[0], err)
+	}
+
+	if proofFile == "" {
+		return fmt.Errorf("--proof-file is required")
+	}
+	raw, err := os.ReadFile(proofFile)
+	if err != nil {
+		return err
+	}
+	proof := new(client.AirdropProof)
+	if err := json.Unmarshal(raw, proof); err != nil {
+		return err
+	}
+	if proof.Index != index {
+		return fmt.Errorf("proof is for index %d, not %d", proof.Index, index)
+	}
+
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	color.Blue("creating requester with URL %s and endpoint %q for airdrop index %d", url, endpoint, index)
+	requester := rpc.NewEndpointRequester(
+		url,
+		endpoint,
+		"quarkvm",
+		requestTimeout,
+	)
+
+	utx := &chain.ClaimAirdropTx{
+		BaseTx: &chain.BaseTx{
+			Sender: signer.SenderBytes(),
+		},
+		Index: proof.Index,
+		Units: proof.Units,
+		Proof: proof.Proof,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	mtx, err := client.Mine(ctx, client.NewRequesterClient(requester), utx, client.MineOptions{})
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	b, err := chain.UnsignedBytes(mtx)
+	if err != nil {
+		return err
+	}
+	a, err := signer.Sign(b)
+	if err != nil {
+		return err
+	}
+	authBytes, err := a.Marshal()
+	if err != nil {
+		return err
+	}
+	tx := chain.NewTx(mtx, authBytes)
+	if err := tx.Init(); err != nil {
+		return err
+	}
+	color.Yellow("Submitting tx %s claiming airdrop index %d", tx.ID(), index)
+
+	resp := new(vm.IssueTxReply)
+	if err := requester.SendRequest(
+		"issueTx",
+		&vm.IssueTxArgs{Tx: tx.Bytes()},
+		resp,
+	); err != nil {
+		color.Red("failed to issue transaction %v", err)
+		return err
+	}
+
+	txID := resp.TxID
+	color.Green("issued transaction %s (success %v)", txID, resp.Success)
+	if !resp.Success {
+		return fmt.Errorf("tx %v failed", txID)
+	}
+
+	color.Yellow("polling transaction %q", txID)
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+done:
+	for ctx.Err() == nil {
+		select {
+		case <-time.After(1 * time.Second):
+		case <-ctx.Done():
+			break done
+		}
+
+		resp := new(vm.CheckTxReply)
+		if err := requester.SendRequest(
+			"checkTx",
+			&vm.CheckTxArgs{TxID: txID},
+			resp,
+		); err != nil {
+			color.Red("polling transaction failed %v", err)
+		}
+		if resp.Confirmed {
+			color.Yellow("confirmed transaction %q", txID)
+			break
+		}
+	}
+	return nil
+}
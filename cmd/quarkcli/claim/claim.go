@@ -11,15 +11,14 @@ import (
 	"strings"
 	"time"
 
-	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/quarkvm/auth"
 	"github.com/ava-labs/quarkvm/chain"
 	"github.com/ava-labs/quarkvm/client"
 	"github.com/ava-labs/quarkvm/cmd/quarkcli/create"
-	"github.com/ava-labs/quarkvm/pow"
 	"github.com/ava-labs/quarkvm/vm"
 )
 
@@ -33,6 +32,7 @@ var (
 	endpoint       string
 	requestTimeout time.Duration
 	prefixInfo     bool
+	authType       string
 )
 
 // NewCommand implements "quark-cli claim" command.
@@ -100,93 +100,17 @@ COMMENT
 		true,
 		"'true' to print out the prefix owner information",
 	)
+	cmd.PersistentFlags().StringVar(
+		&authType,
+		"auth-type",
+		auth.SECP256K1.String(),
+		"auth scheme to sign the tx with ('secp256k1', 'ed25519', or 'bls')",
+	)
 	return cmd
 }
 
-func currBlock(requester rpc.EndpointRequester) (ids.ID, error) {
-	resp := new(vm.CurrBlockReply)
-	if err := requester.SendRequest(
-		"currBlock",
-		&vm.CurrBlockArgs{},
-		resp,
-	); err != nil {
-		color.Red("failed to get curr block %v", err)
-		return ids.ID{}, err
-	}
-	return resp.BlockID, nil
-}
-
-func validBlockID(requester rpc.EndpointRequester, blkID ids.ID) (bool, error) {
-	resp := new(vm.ValidBlockIDReply)
-	if err := requester.SendRequest(
-		"validBlockID",
-		&vm.ValidBlockIDArgs{BlockID: blkID},
-		resp,
-	); err != nil {
-		color.Red("failed to check valid block ID %v", err)
-		return false, err
-	}
-	return resp.Valid, nil
-}
-
-func difficultyEstimate(requester rpc.EndpointRequester) (uint64, error) {
-	resp := new(vm.DifficultyEstimateReply)
-	if err := requester.SendRequest(
-		"difficultyEstimate",
-		&vm.DifficultyEstimateArgs{},
-		resp,
-	); err != nil {
-		color.Red("failed to get difficulty %v", err)
-		return 0, err
-	}
-	return resp.Difficulty, nil
-}
-
-func mine(
-	ctx context.Context,
-	requester rpc.EndpointRequester,
-	utx chain.UnsignedTransaction,
-) (chain.UnsignedTransaction, error) {
-	for ctx.Err() == nil {
-		cbID, err := currBlock(requester)
-		if err != nil {
-			return nil, err
-		}
-		utx.SetBlockID(cbID)
-
-		graffiti := uint64(0)
-		for ctx.Err() == nil {
-			v, err := validBlockID(requester, cbID)
-			if err != nil {
-				return nil, err
-			}
-			if !v {
-				color.Yellow("%v is no longer a valid block id", cbID)
-				break
-			}
-			utx.SetGraffiti(graffiti)
-			b, err := chain.UnsignedBytes(utx)
-			if err != nil {
-				return nil, err
-			}
-			d := pow.Difficulty(b)
-			est, err := difficultyEstimate(requester)
-			if err != nil {
-				return nil, err
-			}
-			if d >= est {
-				return utx, nil
-			}
-			graffiti++
-		}
-		// Get new block hash if no longer valid
-	}
-	return nil, ctx.Err()
-}
-
-// TODO: move all this to a separate client code
 func claimFunc(cmd *cobra.Command, args []string) error {
-	priv, err := create.LoadPK(privateKeyFile)
+	signer, err := create.LoadSigner(authType, privateKeyFile)
 	if err != nil {
 		return err
 	}
@@ -206,14 +130,13 @@ func claimFunc(cmd *cobra.Command, args []string) error {
 
 	utx := &chain.ClaimTx{
 		BaseTx: &chain.BaseTx{
-			Sender: priv.PublicKey().Bytes(),
+			Sender: signer.SenderBytes(),
 			Prefix: pfx,
 		},
 	}
 
-	// TODO: make this a shared lib
 	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-	mtx, err := mine(ctx, requester, utx)
+	mtx, err := client.Mine(ctx, client.NewRequesterClient(requester), utx, client.MineOptions{})
 	cancel()
 	if err != nil {
 		return err
@@ -223,11 +146,15 @@ func claimFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	sig, err := priv.Sign(b)
+	a, err := signer.Sign(b)
+	if err != nil {
+		return err
+	}
+	authBytes, err := a.Marshal()
 	if err != nil {
 		return err
 	}
-	tx := chain.NewTx(mtx, sig)
+	tx := chain.NewTx(mtx, authBytes)
 	if err := tx.Init(); err != nil {
 		return err
 	}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package create loads the private key material quark-cli signs
+// transactions with, one loader per auth.Type scheme, and wraps each key
+// so every command goes through the same [LoadSigner] entry point rather
+// than duplicating per-scheme dispatch logic.
+package create
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PrivateKey wraps a secp256k1 key loaded by [LoadPK].
+type PrivateKey struct {
+	key *ecdsa.PrivateKey
+}
+
+// Sign signs [digest], returning a 65-byte [R || S || V] signature.
+func (p *PrivateKey) Sign(digest []byte) ([]byte, error) {
+	return crypto.Sign(digest, p.key)
+}
+
+// PublicKey returns the wrapped key's public half.
+func (p *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{pub: &p.key.PublicKey}
+}
+
+// PublicKey is the public half of a secp256k1 [PrivateKey].
+type PublicKey struct {
+	pub *ecdsa.PublicKey
+}
+
+// Bytes returns the uncompressed, 65-byte encoding of the public key.
+func (p *PublicKey) Bytes() []byte {
+	return crypto.FromECDSAPub(p.pub)
+}
+
+// LoadPK reads the hex-encoded secp256k1 private key at [file].
+func LoadPK(file string) (*PrivateKey, error) {
+	raw, err := loadHexKey(file)
+	if err != nil {
+		return nil, err
+	}
+	key, err := crypto.ToECDSA(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%q does not contain a valid secp256k1 key: %w", file, err)
+	}
+	return &PrivateKey{key: key}, nil
+}
+
+// EdPrivateKey wraps an Ed25519 key loaded by [LoadEdPK].
+type EdPrivateKey struct {
+	key ed25519.PrivateKey
+}
+
+// Sign signs [digest]. Unlike secp256k1, Ed25519 signing cannot fail.
+func (p *EdPrivateKey) Sign(digest []byte) []byte {
+	return ed25519.Sign(p.key, digest)
+}
+
+// Public returns the wrapped key's public half.
+func (p *EdPrivateKey) Public() ed25519.PublicKey {
+	return p.key.Public().(ed25519.PublicKey)
+}
+
+// LoadEdPK reads the hex-encoded Ed25519 seed at [file].
+func LoadEdPK(file string) (*EdPrivateKey, error) {
+	raw, err := loadHexKey(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%q is %d bytes, expected a %d-byte ed25519 seed", file, len(raw), ed25519.SeedSize)
+	}
+	return &EdPrivateKey{key: ed25519.NewKeyFromSeed(raw)}, nil
+}
+
+// BLSPrivateKey wraps a BLS key loaded by [LoadBLSPK].
+type BLSPrivateKey struct {
+	key *bls.SecretKey
+}
+
+// Sign signs [digest].
+func (p *BLSPrivateKey) Sign(digest []byte) *bls.Signature {
+	return bls.Sign(p.key, digest)
+}
+
+// PublicKey returns the wrapped key's public half.
+func (p *BLSPrivateKey) PublicKey() *bls.PublicKey {
+	return bls.PublicFromSecretKey(p.key)
+}
+
+// LoadBLSPK reads the hex-encoded BLS secret key at [file].
+func LoadBLSPK(file string) (*BLSPrivateKey, error) {
+	raw, err := loadHexKey(file)
+	if err != nil {
+		return nil, err
+	}
+	key, err := bls.SecretKeyFromBytes(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%q does not contain a valid bls key: %w", file, err)
+	}
+	return &BLSPrivateKey{key: key}, nil
+}
+
+// loadHexKey reads [file] and hex-decodes its (whitespace-trimmed)
+// contents, the format every quark-cli key file uses regardless of
+// scheme.
+func loadHexKey(file string) ([]byte, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not hex-encoded: %w", file, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package create
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	"github.com/ava-labs/quarkvm/auth"
+)
+
+// Signer authenticates a transaction with one auth.Type. Every quark-cli
+// command that issues a transaction (claim, export, import,
+// airdrop-claim) builds its BaseTx and signs its digest through a single
+// [Signer] obtained from [LoadSigner], instead of each keeping its own
+// copy of the auth-dispatch logic and its own (potentially different)
+// idea of what BaseTx.Sender should be.
+type Signer interface {
+	// SenderBytes are the raw public-key bytes BaseTx.Sender must carry
+	// so the mined transaction's declared sender always matches whichever
+	// key [Sign] authenticates it with.
+	SenderBytes() []byte
+	// Sign authenticates [digest], returning the auth.Auth to marshal
+	// into the transaction.
+	Sign(digest []byte) (auth.Auth, error)
+}
+
+// LoadSigner loads the key material at [keyFile] for [authType] ('secp256k1',
+// 'ed25519', or 'bls') and returns a [Signer] over it.
+func LoadSigner(authType, keyFile string) (Signer, error) {
+	switch authType {
+	case auth.SECP256K1.String():
+		priv, err := LoadPK(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &secp256k1Signer{priv: priv}, nil
+	case auth.ED25519.String():
+		priv, err := LoadEdPK(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &ed25519Signer{priv: priv}, nil
+	case auth.BLS.String():
+		priv, err := LoadBLSPK(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &blsSigner{priv: priv}, nil
+	default:
+		return nil, fmt.Errorf("unknown auth type %q", authType)
+	}
+}
+
+type secp256k1Signer struct {
+	priv *PrivateKey
+}
+
+func (s *secp256k1Signer) SenderBytes() []byte {
+	return s.priv.PublicKey().Bytes()
+}
+
+func (s *secp256k1Signer) Sign(digest []byte) (auth.Auth, error) {
+	sig, err := s.priv.Sign(digest)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewSECP256K1(sig)
+}
+
+type ed25519Signer struct {
+	priv *EdPrivateKey
+}
+
+func (s *ed25519Signer) SenderBytes() []byte {
+	return s.priv.Public()
+}
+
+func (s *ed25519Signer) Sign(digest []byte) (auth.Auth, error) {
+	return auth.NewED25519(s.priv.Public(), s.priv.Sign(digest))
+}
+
+type blsSigner struct {
+	priv *BLSPrivateKey
+}
+
+func (s *blsSigner) SenderBytes() []byte {
+	return bls.PublicKeyToBytes(s.priv.PublicKey())
+}
+
+func (s *blsSigner) Sign(digest []byte) (auth.Auth, error) {
+	return auth.NewBLS(s.priv.PublicKey(), s.priv.Sign(digest)), nil
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/quarkvm/auth"
+	"github.com/ava-labs/quarkvm/chain"
+	"github.com/ava-labs/quarkvm/client"
+	"github.com/ava-labs/quarkvm/cmd/quarkcli/create"
+	"github.com/ava-labs/quarkvm/vm"
+)
+
+func init() {
+	cobra.EnablePrefixMatching = true
+}
+
+var (
+	privateKeyFile     string
+	url                string
+	endpoint           string
+	requestTimeout     time.Duration
+	authType           string
+	destinationChainID string
+	to                 string
+	units              uint64
+	prefix             string
+)
+
+// NewCommand implements "quark-cli export" command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [options]",
+		Short: "Burns units (or a prefix) on this chain and emits a Warp message for import on another chain",
+		Long: `
+Burns --units (or transfers ownership of --prefix) on this chain and
+issues an "ExportTx" whose Warp message --to can later import on
+--destination-chain-id via "quark-cli import".
+
+# Exports 100 units to chain 2ebCneCb... for 0x000...01 to import
+$ quark-cli export --destination-chain-id=2ebCneCb... --to=0x000...01 --units=100
+<<COMMENT
+success
+COMMENT
+
+`,
+		RunE: exportFunc,
+	}
+	cmd.PersistentFlags().StringVar(
+		&privateKeyFile,
+		"private-key-file",
+		".quark-cli-pk",
+		"private key file path",
+	)
+	cmd.PersistentFlags().StringVar(
+		&url,
+		"url",
+		"http://127.0.0.1:9650",
+		"RPC URL for VM",
+	)
+	cmd.PersistentFlags().StringVar(
+		&endpoint,
+		"endpoint",
+		"",
+		"RPC endpoint for VM",
+	)
+	cmd.PersistentFlags().DurationVar(
+		&requestTimeout,
+		"request-timeout",
+		30*time.Second,
+		"set it to 0 to not wait for transaction confirmation",
+	)
+	cmd.PersistentFlags().StringVar(
+		&authType,
+		"auth-type",
+		auth.SECP256K1.String(),
+		"auth scheme to sign the tx with ('secp256k1', 'ed25519', or 'bls')",
+	)
+	cmd.PersistentFlags().StringVar(
+		&destinationChainID,
+		"destination-chain-id",
+		"",
+		"chain ID the exported value should be imported on",
+	)
+	cmd.PersistentFlags().StringVar(
+		&to,
+		"to",
+		"",
+		"recipient address on the destination chain",
+	)
+	cmd.PersistentFlags().Uint64Var(
+		&units,
+		"units",
+		0,
+		"units to export (ignored if --prefix is set)",
+	)
+	cmd.PersistentFlags().StringVar(
+		&prefix,
+		"prefix",
+		"",
+		"prefix to transfer ownership of instead of exporting units",
+	)
+	return cmd
+}
+
+func exportFunc(cmd *cobra.Command, args []string) error {
+	signer, err := create.LoadSigner(authType, privateKeyFile)
+	if err != nil {
+		return err
+	}
+
+	destChainID, err := ids.FromString(destinationChainID)
+	if err != nil {
+		return fmt.Errorf("invalid --destination-chain-id %q: %w", destinationChainID, err)
+	}
+	if to == "" {
+		return fmt.Errorf("--to is required")
+	}
+	toAddr := common.HexToAddress(to)
+
+	var pfx []byte
+	if prefix != "" {
+		pfx = []byte(prefix)
+		if _, _, _, err := chain.ParseKey(pfx); err != nil {
+			return fmt.Errorf("failed to parse --prefix %v", err)
+		}
+	}
+
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	color.Blue("creating requester with URL %s and endpoint %q for export to %s", url, endpoint, destChainID)
+	requester := rpc.NewEndpointRequester(
+		url,
+		endpoint,
+		"quarkvm",
+		requestTimeout,
+	)
+
+	utx := &chain.ExportTx{
+		BaseTx: &chain.BaseTx{
+			Sender: signer.SenderBytes(),
+			Prefix: pfx,
+		},
+		DestinationChainID: destChainID,
+		To:                 toAddr,
+		Units:              units,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	mtx, err := client.Mine(ctx, client.NewRequesterClient(requester), utx, client.MineOptions{})
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	b, err := chain.UnsignedBytes(mtx)
+	if err != nil {
+		return err
+	}
+	a, err := signer.Sign(b)
+	if err != nil {
+		return err
+	}
+	authBytes, err := a.Marshal()
+	if err != nil {
+		return err
+	}
+	tx := chain.NewTx(mtx, authBytes)
+	if err := tx.Init(); err != nil {
+		return err
+	}
+	color.Yellow("Submitting tx %s exporting to chain %s", tx.ID(), destChainID)
+
+	resp := new(vm.IssueTxReply)
+	if err := requester.SendRequest(
+		"issueTx",
+		&vm.IssueTxArgs{Tx: tx.Bytes()},
+		resp,
+	); err != nil {
+		color.Red("failed to issue transaction %v", err)
+		return err
+	}
+
+	txID := resp.TxID
+	color.Green("issued transaction %s (success %v)", txID, resp.Success)
+	if !resp.Success {
+		return fmt.Errorf("tx %v failed", txID)
+	}
+
+	color.Yellow("polling transaction %q", txID)
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+done:
+	for ctx.Err() == nil {
+		select {
+		case <-time.After(1 * time.Second):
+		case <-ctx.Done():
+			break done
+		}
+
+		resp := new(vm.CheckTxReply)
+		if err := requester.SendRequest(
+			"checkTx",
+			&vm.CheckTxArgs{TxID: txID},
+			resp,
+		); err != nil {
+			color.Red("polling transaction failed %v", err)
+		}
+		if resp.Confirmed {
+			color.Yellow("confirmed transaction %q", txID)
+			break
+		}
+	}
+	return nil
+}
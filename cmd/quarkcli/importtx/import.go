@@ -0,0 +1,199 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package importtx implements "quark-cli import".
+package importtx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/quarkvm/auth"
+	"github.com/ava-labs/quarkvm/chain"
+	"github.com/ava-labs/quarkvm/client"
+	"github.com/ava-labs/quarkvm/cmd/quarkcli/create"
+	"github.com/ava-labs/quarkvm/vm"
+)
+
+func init() {
+	cobra.EnablePrefixMatching = true
+}
+
+var (
+	privateKeyFile string
+	url            string
+	endpoint       string
+	requestTimeout time.Duration
+	authType       string
+	messageFile    string
+)
+
+// NewCommand implements "quark-cli import" command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [options]",
+		Short: "Imports a signed Warp message exported from another chain",
+		Long: `
+Submits an "ImportTx" carrying the signed Warp message at --message-file,
+crediting its recipient on this chain. The message is the JSON-encoded
+"chain.WarpMessage" returned by the source chain's signature aggregator
+once its validators have signed the matching "quark-cli export".
+
+$ quark-cli import --message-file=.quark-cli-warp-message
+<<COMMENT
+success
+COMMENT
+
+`,
+		RunE: importFunc,
+	}
+	cmd.PersistentFlags().StringVar(
+		&privateKeyFile,
+		"private-key-file",
+		".quark-cli-pk",
+		"private key file path",
+	)
+	cmd.PersistentFlags().StringVar(
+		&url,
+		"url",
+		"http://127.0.0.1:9650",
+		"RPC URL for VM",
+	)
+	cmd.PersistentFlags().StringVar(
+		&endpoint,
+		"endpoint",
+		"",
+		"RPC endpoint for VM",
+	)
+	cmd.PersistentFlags().DurationVar(
+		&requestTimeout,
+		"request-timeout",
+		30*time.Second,
+		"set it to 0 to not wait for transaction confirmation",
+	)
+	cmd.PersistentFlags().StringVar(
+		&authType,
+		"auth-type",
+		auth.SECP256K1.String(),
+		"auth scheme to sign the tx with ('secp256k1', 'ed25519', or 'bls')",
+	)
+	cmd.PersistentFlags().StringVar(
+		&messageFile,
+		"message-file",
+		"",
+		"path to the JSON Warp message produced by the source chain's signature aggregator",
+	)
+	return cmd
+}
+
+func importFunc(cmd *cobra.Command, args []string) error {
+	signer, err := create.LoadSigner(authType, privateKeyFile)
+	if err != nil {
+		return err
+	}
+
+	if messageFile == "" {
+		return fmt.Errorf("--message-file is required")
+	}
+	raw, err := os.ReadFile(messageFile)
+	if err != nil {
+		return err
+	}
+	msg := new(chain.WarpMessage)
+	if err := json.Unmarshal(raw, msg); err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	color.Blue("creating requester with URL %s and endpoint %q for import from %s", url, endpoint, msg.Payload.SourceChainID)
+	requester := rpc.NewEndpointRequester(
+		url,
+		endpoint,
+		"quarkvm",
+		requestTimeout,
+	)
+
+	utx := &chain.ImportTx{
+		BaseTx: &chain.BaseTx{
+			Sender: signer.SenderBytes(),
+		},
+		Message: msg,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	mtx, err := client.Mine(ctx, client.NewRequesterClient(requester), utx, client.MineOptions{})
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	b, err := chain.UnsignedBytes(mtx)
+	if err != nil {
+		return err
+	}
+	a, err := signer.Sign(b)
+	if err != nil {
+		return err
+	}
+	authBytes, err := a.Marshal()
+	if err != nil {
+		return err
+	}
+	tx := chain.NewTx(mtx, authBytes)
+	if err := tx.Init(); err != nil {
+		return err
+	}
+	color.Yellow("Submitting tx %s importing from chain %s", tx.ID(), msg.Payload.SourceChainID)
+
+	resp := new(vm.IssueTxReply)
+	if err := requester.SendRequest(
+		"issueTx",
+		&vm.IssueTxArgs{Tx: tx.Bytes()},
+		resp,
+	); err != nil {
+		color.Red("failed to issue transaction %v", err)
+		return err
+	}
+
+	txID := resp.TxID
+	color.Green("issued transaction %s (success %v)", txID, resp.Success)
+	if !resp.Success {
+		return fmt.Errorf("tx %v failed", txID)
+	}
+
+	color.Yellow("polling transaction %q", txID)
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+done:
+	for ctx.Err() == nil {
+		select {
+		case <-time.After(1 * time.Second):
+		case <-ctx.Done():
+			break done
+		}
+
+		resp := new(vm.CheckTxReply)
+		if err := requester.SendRequest(
+			"checkTx",
+			&vm.CheckTxArgs{TxID: txID},
+			resp,
+		); err != nil {
+			color.Red("polling transaction failed %v", err)
+		}
+		if resp.Confirmed {
+			color.Yellow("confirmed transaction %q", txID)
+			break
+		}
+	}
+	return nil
+}